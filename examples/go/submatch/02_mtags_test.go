@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLex(t *testing.T) {
+	var tests = []struct {
+		str string
+		res []string
+	}{
+		{"\000", []string{}},
+		{"one;two;three;\000", []string{"one", "two", "three"}},
+		{"one;two\000", nil},
+	}
+
+	for _, x := range tests {
+		t.Run(x.str, func(t *testing.T) {
+			m := lex(x.str)
+			var res []string
+			if m != nil {
+				res = m.Group(0)
+			}
+			if !reflect.DeepEqual(res, x.res) {
+				t.Errorf("got %v, want %v", res, x.res)
+			}
+		})
+	}
+}
+
+// TestUnwindNestedRepeatedGroups exercises unwind directly against a
+// hand-built trie standing in for a grammar like "((a+);)+", where an inner
+// group is nested inside an outer group that also repeats. It does not go
+// through lex/the generated scanner above: lex's own grammar captures only
+// one group (see the doc comment on lex), so there is no way to drive a
+// nested-group case through it; this test instead exercises unwind in
+// isolation, against the TagPair shape such a grammar would produce, so
+// that unwind's independent-per-group unwinding is covered either way. Each
+// group must be unwound independently so group 0 yields one substring per
+// repetition of the outer group and group 1 one substring per repetition
+// of the inner group.
+func TestUnwindNestedRepeatedGroups(t *testing.T) {
+	str := "aa;a;"
+	trie := createTrie(16)
+	os, oe := mtagRoot, mtagRoot
+	is, ie := mtagRoot, mtagRoot
+
+	// first repetition: "aa;", inner capture "aa"
+	os = mtag(&trie, os, 0)
+	is = mtag(&trie, is, 0)
+	ie = mtag(&trie, ie, 2)
+	oe = mtag(&trie, oe, 3)
+
+	// second repetition: "a;", inner capture "a"
+	os = mtag(&trie, os, 3)
+	is = mtag(&trie, is, 3)
+	ie = mtag(&trie, ie, 4)
+	oe = mtag(&trie, oe, 5)
+
+	m := unwind(trie, []TagPair{{os, oe}, {is, ie}}, str)
+
+	if got, want := m.Group(0), []string{"aa;", "a;"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("group 0: got %v, want %v", got, want)
+	}
+	if got, want := m.Group(1), []string{"aa", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("group 1: got %v, want %v", got, want)
+	}
+}
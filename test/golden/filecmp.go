@@ -0,0 +1,30 @@
+// Package golden implements a filecmp/errchk-style golden-file harness for
+// the Go examples, modelled on the approach used by the gccgo testsuite
+// runner: regenerate each example with the in-tree re2go, diff the result
+// against what is committed, then build and run it and diff stdout against
+// a golden file.
+package golden
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// filecmp reports the first line on which got and want differ, or "" if
+// the two byte slices are identical. Line numbers let a failing test point
+// straight at the emitter change that caused the regression instead of
+// dumping the whole file.
+func filecmp(got, want []byte) string {
+	if bytes.Equal(got, want) {
+		return ""
+	}
+
+	gotLines := bytes.Split(got, []byte("\n"))
+	wantLines := bytes.Split(want, []byte("\n"))
+	for i := 0; i < len(gotLines) && i < len(wantLines); i++ {
+		if !bytes.Equal(gotLines[i], wantLines[i]) {
+			return fmt.Sprintf("line %d:\n got:  %q\n want: %q", i+1, gotLines[i], wantLines[i])
+		}
+	}
+	return fmt.Sprintf("differing line count: got %d, want %d", len(gotLines), len(wantLines))
+}
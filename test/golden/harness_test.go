@@ -0,0 +1,135 @@
+package golden
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// examplesDir holds every *.re source under examples/go. Each one may be
+// paired with:
+//   - a basename.flags file: extra re2go flags on one line, e.g. "-ci" for
+//     conditions or "--utf8" for the rune-aware input mode, so the diverse
+//     invocation modes in this tree are all exercised the same way;
+//   - a basename.txt file: the expected stdout of `go run .` on the
+//     example, checked whether or not re2go was available to regenerate it.
+const examplesDir = "../../examples/go"
+
+// runTimeout bounds the "go run" golden-stdout check so that a regression
+// reintroducing an infinite loop in a generated scanner fails the test
+// instead of hanging the suite forever.
+const runTimeout = 10 * time.Second
+
+// findRe2go locates the in-tree re2go binary. Building it is outside this
+// harness's job (that's the repo's own build system); RE2GO_BIN lets a
+// caller point at a freshly built one, and PATH is the fallback. It returns
+// "" rather than skipping outright, so the golden build-and-run check below
+// can still run against the committed output even when re2go isn't
+// available.
+func findRe2go() string {
+	if bin := os.Getenv("RE2GO_BIN"); bin != "" {
+		return bin
+	}
+	bin, err := exec.LookPath("re2go")
+	if err != nil {
+		return ""
+	}
+	return bin
+}
+
+func readFlags(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(data))
+}
+
+// TestGolden regenerates every discovered .re example (when re2go is
+// available) and checks that the emitter still produces byte-identical
+// output, then builds and runs the example and checks its stdout against a
+// committed golden file. The build-and-run check does not depend on re2go:
+// it exercises whatever .go is on disk, so it still catches behavioral
+// regressions in a hand-maintained fixture (truncated matches, infinite
+// loops on EOF, stale offsets after a buffer shift) even before the emitter
+// change that would normally produce that fixture exists.
+func TestGolden(t *testing.T) {
+	res, err := filepath.Glob(filepath.Join(examplesDir, "*", "*.re"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res2, err := filepath.Glob(filepath.Join(examplesDir, "*", "*", "*.re"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := append(res, res2...)
+	if len(sources) == 0 {
+		t.Skip("no .re examples found under " + examplesDir)
+	}
+
+	re2go := findRe2go()
+	if re2go == "" {
+		t.Log("re2go binary not found (set RE2GO_BIN or add it to PATH); skipping regeneration diff, still building and running each example")
+	}
+
+	for _, src := range sources {
+		src := src
+		name := strings.TrimSuffix(filepath.Base(src), ".re")
+		dir := filepath.Dir(src)
+		t.Run(filepath.Join(filepath.Base(dir), name), func(t *testing.T) {
+			expected := filepath.Join(dir, name+".go")
+			want, err := os.ReadFile(expected)
+			if err != nil {
+				t.Fatalf("no committed output to compare against: %v", err)
+			}
+
+			if re2go != "" {
+				out := filepath.Join(t.TempDir(), name+".go")
+				args := append(readFlags(filepath.Join(dir, name+".flags")), src, "-o", out)
+				cmd := exec.Command(re2go, args...)
+				if diff, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("re2go %v: %v\n%s", args, err, diff)
+				}
+
+				got, err := os.ReadFile(out)
+				if err != nil {
+					t.Fatalf("reading regenerated output: %v", err)
+				}
+				if diff := filecmp(got, want); diff != "" {
+					t.Errorf("regenerated output differs from %s:\n%s", expected, diff)
+				}
+			}
+
+			goldenStdout := filepath.Join(dir, name+".txt")
+			wantStdout, err := os.ReadFile(goldenStdout)
+			if err != nil {
+				return // no golden stdout to check for this example
+			}
+
+			gomodCmd := exec.Command("go", "env", "GOMOD")
+			gomodCmd.Dir = dir
+			if gomod, err := gomodCmd.Output(); err != nil || strings.TrimSpace(string(gomod)) == os.DevNull {
+				t.Skip("no enclosing go.mod for this example; skipping build-and-run check")
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+			defer cancel()
+			cmd := exec.CommandContext(ctx, "go", "run", ".")
+			cmd.Dir = dir
+			gotStdout, err := cmd.Output()
+			if ctx.Err() == context.DeadlineExceeded {
+				t.Fatalf("go run %s: did not finish within %s (possible infinite loop)", dir, runTimeout)
+			}
+			if err != nil {
+				t.Fatalf("go run %s: %v", dir, err)
+			}
+			if diff := filecmp(gotStdout, wantStdout); diff != "" {
+				t.Errorf("stdout differs from %s:\n%s", goldenStdout, diff)
+			}
+		})
+	}
+}